@@ -0,0 +1,66 @@
+package main
+
+import (
+    "math"
+
+    "github.com/hajimehoshi/ebiten/v2"
+)
+
+// BackgroundSprites holds one star/nebula image per parallax layer, farthest
+// layer first.
+var BackgroundSprites []*ebiten.Image
+
+func loadBackgroundLayers() {
+    BackgroundSprites = mustLoadImages("assets/background")
+}
+
+// bgDrift is a small constant scroll applied to every layer regardless of
+// player movement, so the field never looks fully static.
+const bgDrift = 0.15
+
+// backgroundLayer tracks the scroll offset for one parallax layer. Layers
+// closer to the front (higher index) scroll faster, following the player
+// more closely, which is what sells the depth illusion.
+type backgroundLayer struct {
+    sprite  *ebiten.Image
+    offsetX float64
+    offsetY float64
+    speed   float64
+}
+
+func newBackgroundLayers() []*backgroundLayer {
+    n := len(BackgroundSprites)
+    layers := make([]*backgroundLayer, n)
+    for i, sprite := range BackgroundSprites {
+        layers[i] = &backgroundLayer{
+            sprite: sprite,
+            speed:  float64(i+1) / float64(n),
+        }
+    }
+    return layers
+}
+
+func (l *backgroundLayer) Update(playerDelta Vector) {
+    b := l.sprite.Bounds()
+    w := float64(b.Dx())
+    h := float64(b.Dy())
+    l.offsetX = math.Mod(l.offsetX-playerDelta.X*l.speed+bgDrift*l.speed, w)
+    l.offsetY = math.Mod(l.offsetY-playerDelta.Y*l.speed, h)
+}
+
+// Draw tiles the layer's sprite across the screen, wrapping the scroll
+// offset around so it repeats seamlessly in both axes.
+func (l *backgroundLayer) Draw(screen *ebiten.Image) {
+    b := l.sprite.Bounds()
+    w := float64(b.Dx())
+    h := float64(b.Dy())
+    startX := math.Mod(l.offsetX, w) - w
+    startY := math.Mod(l.offsetY, h) - h
+    for x := startX; x < ScreenWidth; x += w {
+        for y := startY; y < ScreenHeight; y += h {
+            op := &ebiten.DrawImageOptions{}
+            op.GeoM.Translate(x, y)
+            screen.DrawImage(l.sprite, op)
+        }
+    }
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+    "encoding/json"
+    "os"
+    "path/filepath"
+
+    "github.com/hajimehoshi/ebiten/v2"
+)
+
+// gamepadDeadzone ignores small stick drift around center.
+const gamepadDeadzone = 0.2
+
+// startButtons mirrors the carotidartillery convention of treating several
+// standard-layout buttons as "start", since controllers disagree on which
+// of the two center buttons that is.
+var startButtons = []ebiten.StandardGamepadButton{
+    ebiten.StandardGamepadButtonCenterRight,
+    ebiten.StandardGamepadButtonCenterLeft,
+}
+
+// KeyBindings holds the keyboard keys used for each action. Gamepad mapping
+// is fixed to the standard layout and isn't user-configurable.
+type KeyBindings struct {
+    Left   ebiten.Key
+    Right  ebiten.Key
+    Up     ebiten.Key
+    Down   ebiten.Key
+    Shoot  ebiten.Key
+    Rewind ebiten.Key
+}
+
+func defaultKeyBindings() KeyBindings {
+    return KeyBindings{
+        Left:   ebiten.KeyLeft,
+        Right:  ebiten.KeyRight,
+        Up:     ebiten.KeyUp,
+        Down:   ebiten.KeyDown,
+        Shoot:  ebiten.KeySpace,
+        Rewind: ebiten.KeyR,
+    }
+}
+
+// keyNames maps the JSON config's human-readable key names to ebiten.Key
+// values.
+var keyNames = map[string]ebiten.Key{
+    "Left":  ebiten.KeyLeft,
+    "Right": ebiten.KeyRight,
+    "Up":    ebiten.KeyUp,
+    "Down":  ebiten.KeyDown,
+    "Space": ebiten.KeySpace,
+    "W":     ebiten.KeyW,
+    "A":     ebiten.KeyA,
+    "S":     ebiten.KeyS,
+    "D":     ebiten.KeyD,
+    "R":     ebiten.KeyR,
+}
+
+type keyBindingsConfig struct {
+    Left   string `json:"left"`
+    Right  string `json:"right"`
+    Up     string `json:"up"`
+    Down   string `json:"down"`
+    Shoot  string `json:"shoot"`
+    Rewind string `json:"rewind"`
+}
+
+// loadKeyBindings reads keybinding overrides from
+// os.UserConfigDir()/starship/keybindings.json, falling back to the default
+// bindings for any key that's missing, unreadable, or unrecognized.
+func loadKeyBindings() KeyBindings {
+    bindings := defaultKeyBindings()
+    dir, err := os.UserConfigDir()
+    if err != nil {
+        return bindings
+    }
+    data, err := os.ReadFile(filepath.Join(dir, "starship", "keybindings.json"))
+    if err != nil {
+        return bindings
+    }
+    var cfg keyBindingsConfig
+    if err := json.Unmarshal(data, &cfg); err != nil {
+        return bindings
+    }
+    if k, ok := keyNames[cfg.Left]; ok {
+        bindings.Left = k
+    }
+    if k, ok := keyNames[cfg.Right]; ok {
+        bindings.Right = k
+    }
+    if k, ok := keyNames[cfg.Up]; ok {
+        bindings.Up = k
+    }
+    if k, ok := keyNames[cfg.Down]; ok {
+        bindings.Down = k
+    }
+    if k, ok := keyNames[cfg.Shoot]; ok {
+        bindings.Shoot = k
+    }
+    if k, ok := keyNames[cfg.Rewind]; ok {
+        bindings.Rewind = k
+    }
+    return bindings
+}
+
+// InputState abstracts the direct ebiten.IsKeyPressed calls scattered
+// through Player.Update and Game.Update, polling the keyboard and (once
+// connected) the first gamepad using the standard layout.
+type InputState struct {
+    bindings  KeyBindings
+    gamepadID ebiten.GamepadID
+    connected bool
+}
+
+func NewInputState() *InputState {
+    return &InputState{bindings: loadKeyBindings()}
+}
+
+// SetGamepad records the gamepad Game.Update just detected as connected.
+func (in *InputState) SetGamepad(id ebiten.GamepadID, connected bool) {
+    in.gamepadID = id
+    in.connected = connected
+}
+
+func (in *InputState) Left() bool {
+    if in.connected && ebiten.StandardGamepadAxisValue(in.gamepadID, ebiten.StandardGamepadAxisLeftStickHorizontal) < -gamepadDeadzone {
+        return true
+    }
+    return ebiten.IsKeyPressed(in.bindings.Left)
+}
+
+func (in *InputState) Right() bool {
+    if in.connected && ebiten.StandardGamepadAxisValue(in.gamepadID, ebiten.StandardGamepadAxisLeftStickHorizontal) > gamepadDeadzone {
+        return true
+    }
+    return ebiten.IsKeyPressed(in.bindings.Right)
+}
+
+func (in *InputState) Thrust() bool {
+    if in.connected && ebiten.StandardGamepadAxisValue(in.gamepadID, ebiten.StandardGamepadAxisLeftStickVertical) < -gamepadDeadzone {
+        return true
+    }
+    return ebiten.IsKeyPressed(in.bindings.Up)
+}
+
+func (in *InputState) Reverse() bool {
+    if in.connected && ebiten.StandardGamepadAxisValue(in.gamepadID, ebiten.StandardGamepadAxisLeftStickVertical) > gamepadDeadzone {
+        return true
+    }
+    return ebiten.IsKeyPressed(in.bindings.Down)
+}
+
+func (in *InputState) Shoot() bool {
+    if in.connected && ebiten.IsStandardGamepadButtonPressed(in.gamepadID, ebiten.StandardGamepadButtonRightBottom) {
+        return true
+    }
+    return ebiten.IsKeyPressed(in.bindings.Shoot)
+}
+
+// Rewind reports whether the player is holding the rewind control, via the
+// bound key or the gamepad's left shoulder button.
+func (in *InputState) Rewind() bool {
+    if in.connected && ebiten.IsStandardGamepadButtonPressed(in.gamepadID, ebiten.StandardGamepadButtonFrontTopLeft) {
+        return true
+    }
+    return ebiten.IsKeyPressed(in.bindings.Rewind)
+}
+
+// Restart reports whether the player asked to restart from the game-over
+// screen, via the space key or any of the gamepad's start-like buttons.
+func (in *InputState) Restart() bool {
+    if in.connected {
+        for _, btn := range startButtons {
+            if ebiten.IsStandardGamepadButtonPressed(in.gamepadID, btn) {
+                return true
+            }
+        }
+    }
+    return ebiten.IsKeyPressed(ebiten.KeySpace)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+    "image"
+    "image/color"
+    "math"
+
+    "github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+    baseTorchRadius   = 150.0
+    bulletHaloRadius  = 24.0
+    lightGradientSize = 256 // gradient sprites are generated once at this resolution, then scaled
+)
+
+var (
+    torchGradient *ebiten.Image
+    haloGradient  *ebiten.Image
+)
+
+// generateRadialGradient renders a soft white circle, full alpha at the
+// center fading to none at the edge, used as a reusable "light" stamp.
+func generateRadialGradient(size int) *ebiten.Image {
+    img := image.NewRGBA(image.Rect(0, 0, size, size))
+    center := float64(size) / 2
+    for y := 0; y < size; y++ {
+        for x := 0; x < size; x++ {
+            dist := math.Hypot(float64(x)-center, float64(y)-center)
+            t := dist / center
+            if t > 1 {
+                t = 1
+            }
+            a := uint8((1 - t) * 255)
+            img.Set(x, y, color.NRGBA{255, 255, 255, a})
+        }
+    }
+    return ebiten.NewImageFromImage(img)
+}
+
+func init() {
+    torchGradient = generateRadialGradient(lightGradientSize)
+    haloGradient = generateRadialGradient(lightGradientSize)
+}
+
+// drawLightMask darkens everything outside the player's torch (and small
+// halos around each bullet) down to minLevelColorScale, giving meteors
+// outside the lit radius a dim warning silhouette instead of hiding them.
+func (g *Game) drawLightMask(screen *ebiten.Image) {
+    if g.lightMask == nil {
+        g.lightMask = ebiten.NewImage(ScreenWidth, ScreenHeight)
+    }
+    floor := uint8(g.minLevelColorScale * 255)
+    g.lightMask.Fill(color.RGBA{floor, floor, floor, 255})
+
+    radius := baseTorchRadius * g.minPlayerColorScale
+    drawLightStamp(g.lightMask, torchGradient, g.player.position, radius)
+
+    for _, b := range g.bullets {
+        drawLightStamp(g.lightMask, haloGradient, b.position, bulletHaloRadius)
+    }
+
+    op := &ebiten.DrawImageOptions{}
+    op.CompositeMode = ebiten.CompositeModeMultiply
+    screen.DrawImage(g.lightMask, op)
+}
+
+// drawLightStamp additively blends a gradient sprite into dst, centered on
+// center and scaled to the given radius.
+func drawLightStamp(dst, gradient *ebiten.Image, center Vector, radius float64) {
+    scale := (radius * 2) / lightGradientSize
+    op := &ebiten.DrawImageOptions{}
+    op.CompositeMode = ebiten.CompositeModeLighter
+    op.GeoM.Scale(scale, scale)
+    op.GeoM.Translate(center.X-radius, center.Y-radius)
+    dst.DrawImage(gradient, op)
+}
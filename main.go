@@ -2,7 +2,6 @@ package main
 
 import (
 	"embed"
-	"fmt"
 	"image"
 	"image/color"
 	_ "image/png"
@@ -11,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
@@ -85,6 +85,8 @@ func init() {
     PlayerSprite = mustLoadImage("assets/player.png")
     BulletSprite = mustLoadImage("assets/bullet.png")
     MeteorSprites = mustLoadImages("assets/meteors")
+    loadPowerupSprites()
+    loadBackgroundLayers()
     ScoreFont = mustLoadFont("assets/font.ttf")
     // Load game over font with larger size
     data, err := assets.ReadFile("assets/font.ttf")
@@ -167,43 +169,89 @@ func (r Rect) Intersects(o Rect) bool {
 // Game is our Ebiten game state.
 // :contentReference[oaicite:4]{index=4}
 type Game struct {
-    player           *Player
-    meteorSpawnTimer *Timer
-    meteors          []*Meteor
-    bullets          []*Bullet
-    score            int
-    difficultyLevel  int
-    isGameOver       bool    // new: track game over state
-    rainbowHue       float64 // new: for rainbow animation
+    player              *Player
+    meteorSpawnTimer    *Timer
+    meteors             []Meteor
+    bullets             []*Bullet
+    powerupSpawnTimer   *Timer
+    powerups            []*Powerup
+    background          []*backgroundLayer
+    score               int
+    difficultyLevel     int
+    isGameOver          bool    // new: track game over state
+    rainbowHue          float64 // new: for rainbow animation
+    tick                int     // ticks elapsed this run, used to timestamp rewind snapshots
+    gameOverTick        int     // tick at which isGameOver was set
+    lightMask           *ebiten.Image
+    minLevelColorScale  float64 // floor brightness applied outside the torch radius
+    minPlayerColorScale float64 // multiplier on the player's torch radius
+    fullBrightMode      bool    // debug toggle (F2) that skips the lighting pass
+    input               *InputState
+    highScores          []HighScoreEntry
+    preDeathHighScores  []HighScoreEntry // highScores before this run's provisional insert, for undoing a rewind out of death
+    lastRank            int // 1-based rank just achieved on the high-score table, 0 if none
+    shotsFired          int
+    hits                int
+    peakDifficulty      int
 }
 
 func NewGame() *Game {
     g := &Game{
-        meteors: make([]*Meteor, 0),
-        bullets: make([]*Bullet, 0),
+        meteors:             make([]Meteor, 0),
+        bullets:             make([]*Bullet, 0),
+        powerups:            make([]*Powerup, 0),
+        minLevelColorScale:  0.15,
+        minPlayerColorScale: 1.0,
+        input:               NewInputState(),
+        highScores:          loadHighScores(),
     }
     g.player = NewPlayer(g, 0)
     g.meteorSpawnTimer = NewTimer(1 * time.Second)
+    g.powerupSpawnTimer = NewTimer(8 * time.Second)
+    g.background = newBackgroundLayers()
     return g
 }
 
 func (g *Game) Update() error {
+    if ids := inpututil.AppendJustConnectedGamepadIDs(nil); len(ids) > 0 {
+        g.input.SetGamepad(ids[0], true)
+    }
+
+    if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+        g.fullBrightMode = !g.fullBrightMode
+    }
+
+    if g.input.Rewind() && g.player.positionHistory.Len() > 0 {
+        g.rewind()
+        return nil
+    }
+
     if g.isGameOver {
         // Update rainbow hue
         g.rainbowHue += 0.01
         if g.rainbowHue > 1.0 {
             g.rainbowHue = 0
         }
-        
-        // Check for space key to restart
-        if ebiten.IsKeyPressed(ebiten.KeySpace) {
+
+        // Check for space key or gamepad start button to restart
+        if g.input.Restart() {
+            // The run can no longer be rewound away, so its high score (if
+            // any) is now final; commit it to disk before resetting.
+            saveHighScores(g.highScores)
             g.Reset()
         }
         return nil
     }
 
+    g.tick++
     g.difficultyLevel = g.score / 10
+    if g.difficultyLevel > g.peakDifficulty {
+        g.peakDifficulty = g.difficultyLevel
+    }
     g.player.Update(g.difficultyLevel)
+    for _, layer := range g.background {
+        layer.Update(g.player.lastMove)
+    }
 
     g.meteorSpawnTimer.Update()
     if g.meteorSpawnTimer.IsReady() {
@@ -212,17 +260,40 @@ func (g *Game) Update() error {
     }
 
     for _, m := range g.meteors {
-        m.Update()
+        m.Update(g.player)
     }
     for _, b := range g.bullets {
         b.Update()
     }
 
+    g.powerupSpawnTimer.Update()
+    if g.powerupSpawnTimer.IsReady() {
+        g.powerupSpawnTimer.Reset()
+        g.powerups = append(g.powerups, NewPowerup())
+    }
+    for i := len(g.powerups) - 1; i >= 0; i-- {
+        pu := g.powerups[i]
+        pu.Update()
+        if pu.Expired() {
+            g.powerups = append(g.powerups[:i], g.powerups[i+1:]...)
+        }
+    }
+
     // handle collisions
     for i := len(g.meteors) - 1; i >= 0; i-- {
         m := g.meteors[i]
         if m.Collider().Intersects(g.player.Collider()) {
+            if shield, ok := g.player.activeEffects[EffectShield]; ok && !shield.IsReady() {
+                g.meteors = append(g.meteors[:i], g.meteors[i+1:]...)
+                continue
+            }
             g.isGameOver = true
+            g.gameOverTick = g.tick
+            // Provisional only: rewind can still undo this death, so the
+            // table isn't written to disk until the player commits to it by
+            // restarting instead of rewinding.
+            g.preDeathHighScores = append([]HighScoreEntry(nil), g.highScores...)
+            g.highScores, g.lastRank = recordHighScore(g.highScores, g.score, time.Now())
             return nil
         }
         for j := len(g.bullets) - 1; j >= 0; j-- {
@@ -231,6 +302,7 @@ func (g *Game) Update() error {
                 g.meteors = append(g.meteors[:i], g.meteors[i+1:]...)
                 g.bullets = append(g.bullets[:j], g.bullets[j+1:]...)
                 g.score++
+                g.hits++
                 break
             }
         }
@@ -247,19 +319,25 @@ func (g *Game) Draw(screen *ebiten.Image) {
             ScreenWidth/2-200, ScreenHeight/2-50, color.White)
         
         // Score text
-        text.Draw(screen, fmt.Sprintf("Score: %06d", g.score), ScoreFont,
+        text.Draw(screen, numberPrinter.Sprintf("Score: %d", g.score), ScoreFont,
             ScreenWidth/2-100, ScreenHeight/2, color.White)
-        
+
         // Rainbow "Try Again" text
-        r, g, b := hsvToRGB(g.rainbowHue, 1.0, 1.0)
-        rainbowColor := color.RGBA{r, g, b, 255}
-        
+        rr, gg, bb := hsvToRGB(g.rainbowHue, 1.0, 1.0)
+        rainbowColor := color.RGBA{rr, gg, bb, 255}
+
         // Draw Try Again text with rainbow color
         text.Draw(screen, "Try Again", ScoreFont,
             ScreenWidth/2-80, ScreenHeight*3/4, rainbowColor)
+
+        drawRunStats(screen, g)
+        drawHighScores(screen, g)
         return
     }
 
+    for _, layer := range g.background {
+        layer.Draw(screen)
+    }
     g.player.Draw(screen)
     for _, m := range g.meteors {
         m.Draw(screen)
@@ -267,9 +345,16 @@ func (g *Game) Draw(screen *ebiten.Image) {
     for _, b := range g.bullets {
         b.Draw(screen)
     }
+    for _, pu := range g.powerups {
+        pu.Draw(screen)
+    }
+    if !g.fullBrightMode {
+        g.drawLightMask(screen)
+    }
     // draw score UI
-    text.Draw(screen, fmt.Sprintf("%06d", g.score), ScoreFont,
+    text.Draw(screen, numberPrinter.Sprintf("%d", g.score), ScoreFont,
         ScreenWidth/2-100, 50, color.White)
+    drawActiveEffects(screen, g.player)
 }
 
 func (g *Game) Layout(outW, outH int) (int, int) {
@@ -280,11 +365,22 @@ func (g *Game) Reset() {
     g.player = NewPlayer(g, 0)
     g.meteors = g.meteors[:0]
     g.bullets = g.bullets[:0]
+    g.powerups = g.powerups[:0]
     g.meteorSpawnTimer.Reset()
+    g.powerupSpawnTimer.Reset()
+    g.background = newBackgroundLayers()
     g.score = 0
     g.difficultyLevel = 0
     g.isGameOver = false
     g.rainbowHue = 0
+    g.tick = 0
+    g.gameOverTick = 0
+    g.minPlayerColorScale = 1.0
+    g.preDeathHighScores = nil
+    g.lastRank = 0
+    g.shotsFired = 0
+    g.hits = 0
+    g.peakDifficulty = 0
 }
 
 func (g *Game) AddBullet(b *Bullet) {
@@ -294,13 +390,16 @@ func (g *Game) AddBullet(b *Bullet) {
 // Player represents the starship.
 // :contentReference[oaicite:5]{index=5}
 type Player struct {
-    position      Vector
-    rotation      float64
-    sprite        *ebiten.Image
-    game          *Game
-    shootCooldown *Timer
-    baseRotSpeed  float64
-    baseCooldown  time.Duration
+    position        Vector
+    rotation        float64
+    sprite          *ebiten.Image
+    game            *Game
+    shootCooldown   *Timer
+    baseRotSpeed    float64
+    baseCooldown    time.Duration
+    activeEffects   map[EffectKind]*Timer
+    lastMove        Vector // displacement applied this tick, for the parallax background
+    positionHistory *positionHistory
 }
 
 func NewPlayer(g *Game, difficulty int) *Player {
@@ -319,43 +418,68 @@ func NewPlayer(g *Game, difficulty int) *Player {
         cooldown = time.Duration(float64(baseCooldown) * math.Pow(0.92, float64(difficulty)))
     }
     return &Player{
-        position:      pos,
-        sprite:        sprite,
-        game:          g,
-        shootCooldown: NewTimer(cooldown),
-        baseRotSpeed:  baseRotSpeed,
-        baseCooldown:  baseCooldown,
+        position:        pos,
+        sprite:          sprite,
+        game:            g,
+        shootCooldown:   NewTimer(cooldown),
+        baseRotSpeed:    baseRotSpeed,
+        baseCooldown:    baseCooldown,
+        activeEffects:   make(map[EffectKind]*Timer),
+        positionHistory: newPositionHistory(maxHistoryTicks()),
     }
 }
 
 func (p *Player) Update(difficulty int) {
+    input := p.game.input
     rotSpeed := p.baseRotSpeed * (1 + 0.08*float64(difficulty))
-    if ebiten.IsKeyPressed(ebiten.KeyLeft) {
+    if input.Left() {
         p.rotation -= rotSpeed
     }
-    if ebiten.IsKeyPressed(ebiten.KeyRight) {
+    if input.Right() {
         p.rotation += rotSpeed
     }
     // thrust
     baseSpeed := 2.0
     speed := baseSpeed * (1 + 0.05*float64(difficulty))
-    if ebiten.IsKeyPressed(ebiten.KeyUp) {
-        p.position.X += math.Sin(p.rotation) * speed
-        p.position.Y -= math.Cos(p.rotation) * speed
+    p.lastMove = Vector{}
+    if input.Thrust() {
+        p.lastMove.X += math.Sin(p.rotation) * speed
+        p.lastMove.Y -= math.Cos(p.rotation) * speed
+    }
+    if input.Reverse() {
+        p.lastMove.X -= math.Sin(p.rotation) * speed
+        p.lastMove.Y += math.Cos(p.rotation) * speed
+    }
+    p.position.X += p.lastMove.X
+    p.position.Y += p.lastMove.Y
+    // pickups
+    for i := len(p.game.powerups) - 1; i >= 0; i-- {
+        pu := p.game.powerups[i]
+        if pu.Collider().Intersects(p.Collider()) {
+            p.applyPowerup(pu.kind)
+            p.game.powerups = append(p.game.powerups[:i], p.game.powerups[i+1:]...)
+        }
     }
-    if ebiten.IsKeyPressed(ebiten.KeyDown) {
-        p.position.X -= math.Sin(p.rotation) * speed
-        p.position.Y += math.Cos(p.rotation) * speed
+    for kind, t := range p.activeEffects {
+        t.Update()
+        if t.IsReady() {
+            delete(p.activeEffects, kind)
+        }
     }
+
     // shoot
     newCooldown := p.baseCooldown
     if difficulty > 0 {
         newCooldown = time.Duration(float64(p.baseCooldown) * math.Pow(0.92, float64(difficulty)))
     }
+    if _, ok := p.activeEffects[EffectRapidFire]; ok {
+        newCooldown = time.Duration(float64(newCooldown) * rapidFireFactor)
+    }
     p.shootCooldown.targetTicks = int(newCooldown.Seconds() * float64(ebiten.TPS()))
     p.shootCooldown.Update()
-    if p.shootCooldown.IsReady() && ebiten.IsKeyPressed(ebiten.KeySpace) {
+    if p.shootCooldown.IsReady() && input.Shoot() {
         p.shootCooldown.Reset()
+        p.game.shotsFired++
         b := p.sprite.Bounds()
         halfW := float64(b.Dx()) / 2
         halfH := float64(b.Dy()) / 2
@@ -366,6 +490,8 @@ func (p *Player) Update(difficulty int) {
         }
         p.game.AddBullet(NewBullet(spawn, p.rotation))
     }
+
+    p.recordSnapshot(p.game.tick)
 }
 
 func (p *Player) Draw(screen *ebiten.Image) {
@@ -386,58 +512,6 @@ func (p *Player) Collider() Rect {
         float64(b.Dx()), float64(b.Dy()))
 }
 
-// Meteor storms in toward the center.
-// :contentReference[oaicite:6]{index=6}
-type Meteor struct {
-    position      Vector
-    movement      Vector
-    rotation      float64
-    rotationSpeed float64
-    sprite        *ebiten.Image
-}
-
-func NewMeteor(difficulty int) *Meteor {
-    sprite := MeteorSprites[rand.Intn(len(MeteorSprites))]
-    // spawn on circle around center
-    angle := rand.Float64() * 2 * math.Pi
-    r := float64(ScreenWidth) / 2
-    x := ScreenWidth/2 + math.Cos(angle)*r
-    y := ScreenHeight/2 + math.Sin(angle)*r
-    dir := Vector{X: ScreenWidth/2 - x, Y: ScreenHeight/2 - y}.Normalize()
-    baseVel := 0.3 + rand.Float64()*0.7
-    vel := baseVel * (1 + 0.12*float64(difficulty))
-    return &Meteor{
-        position:      Vector{X: x, Y: y},
-        movement:      Vector{X: dir.X * vel, Y: dir.Y * vel},
-        rotationSpeed: (-0.02 + rand.Float64()*0.04) * (1 + 0.10*float64(difficulty)),
-        sprite:        sprite,
-    }
-}
-
-func (m *Meteor) Update() {
-    m.position.X += m.movement.X
-    m.position.Y += m.movement.Y
-    m.rotation += m.rotationSpeed
-}
-
-func (m *Meteor) Draw(screen *ebiten.Image) {
-    b := m.sprite.Bounds()
-    halfW := float64(b.Dx()) / 2
-    halfH := float64(b.Dy()) / 2
-    op := &ebiten.DrawImageOptions{}
-    op.GeoM.Translate(-halfW, -halfH)
-    op.GeoM.Rotate(m.rotation)
-    op.GeoM.Translate(halfW, halfH)
-    op.GeoM.Translate(m.position.X, m.position.Y)
-    screen.DrawImage(m.sprite, op)
-}
-
-func (m *Meteor) Collider() Rect {
-    b := m.sprite.Bounds()
-    return NewRect(m.position.X, m.position.Y,
-        float64(b.Dx()), float64(b.Dy()))
-}
-
 // Bullet flies straight out.
 // :contentReference[oaicite:7]{index=7}
 type Bullet struct {
@@ -0,0 +1,208 @@
+package main
+
+import (
+    "math"
+    "math/rand"
+
+    "github.com/hajimehoshi/ebiten/v2"
+)
+
+// Meteor is any hostile entity that streams in toward the player's play
+// field. BasicMeteor, SeekerMeteor, and EvasiveBat all satisfy it, and the
+// Bullet collision loop only ever needs Collider().
+type Meteor interface {
+    Update(player *Player)
+    Draw(screen *ebiten.Image)
+    Collider() Rect
+}
+
+// NewMeteor picks an enemy class to spawn, with seeker and bat frequencies
+// scaling up as difficultyLevel rises.
+func NewMeteor(difficulty int) Meteor {
+    seekerChance := math.Min(0.10+0.03*float64(difficulty), 0.5)
+    batChance := math.Min(0.05+0.02*float64(difficulty), 0.3)
+    roll := rand.Float64()
+    switch {
+    case roll < seekerChance:
+        return NewSeekerMeteor(difficulty)
+    case roll < seekerChance+batChance:
+        return NewEvasiveBat(difficulty)
+    default:
+        return NewBasicMeteor(difficulty)
+    }
+}
+
+// spawnOnRing picks a point on a circle around the screen center, as every
+// enemy class enters from off in the same way.
+func spawnOnRing() Vector {
+    angle := rand.Float64() * 2 * math.Pi
+    r := float64(ScreenWidth) / 2
+    return Vector{
+        X: ScreenWidth/2 + math.Cos(angle)*r,
+        Y: ScreenHeight/2 + math.Sin(angle)*r,
+    }
+}
+
+// BasicMeteor storms in toward the center on a straight line.
+// :contentReference[oaicite:6]{index=6}
+type BasicMeteor struct {
+    position      Vector
+    movement      Vector
+    rotation      float64
+    rotationSpeed float64
+    sprite        *ebiten.Image
+}
+
+func NewBasicMeteor(difficulty int) *BasicMeteor {
+    sprite := MeteorSprites[rand.Intn(len(MeteorSprites))]
+    pos := spawnOnRing()
+    dir := Vector{X: ScreenWidth/2 - pos.X, Y: ScreenHeight/2 - pos.Y}.Normalize()
+    baseVel := 0.3 + rand.Float64()*0.7
+    vel := baseVel * (1 + 0.12*float64(difficulty))
+    return &BasicMeteor{
+        position:      pos,
+        movement:      Vector{X: dir.X * vel, Y: dir.Y * vel},
+        rotationSpeed: (-0.02 + rand.Float64()*0.04) * (1 + 0.10*float64(difficulty)),
+        sprite:        sprite,
+    }
+}
+
+func (m *BasicMeteor) Update(player *Player) {
+    m.position.X += m.movement.X
+    m.position.Y += m.movement.Y
+    m.rotation += m.rotationSpeed
+}
+
+func (m *BasicMeteor) Draw(screen *ebiten.Image) {
+    b := m.sprite.Bounds()
+    halfW := float64(b.Dx()) / 2
+    halfH := float64(b.Dy()) / 2
+    op := &ebiten.DrawImageOptions{}
+    op.GeoM.Translate(-halfW, -halfH)
+    op.GeoM.Rotate(m.rotation)
+    op.GeoM.Translate(halfW, halfH)
+    op.GeoM.Translate(m.position.X, m.position.Y)
+    screen.DrawImage(m.sprite, op)
+}
+
+func (m *BasicMeteor) Collider() Rect {
+    b := m.sprite.Bounds()
+    return NewRect(m.position.X, m.position.Y,
+        float64(b.Dx()), float64(b.Dy()))
+}
+
+// SeekerMeteor steers toward the player's current position each tick,
+// blending its old heading with the desired one at a capped turn rate.
+type SeekerMeteor struct {
+    position      Vector
+    movement      Vector
+    rotation      float64
+    rotationSpeed float64
+    sprite        *ebiten.Image
+    speed         float64
+}
+
+func NewSeekerMeteor(difficulty int) *SeekerMeteor {
+    sprite := MeteorSprites[rand.Intn(len(MeteorSprites))]
+    pos := spawnOnRing()
+    dir := Vector{X: ScreenWidth/2 - pos.X, Y: ScreenHeight/2 - pos.Y}.Normalize()
+    baseSpeed := 0.4 + rand.Float64()*0.6
+    speed := baseSpeed * (1 + 0.12*float64(difficulty))
+    return &SeekerMeteor{
+        position:      pos,
+        movement:      Vector{X: dir.X * speed, Y: dir.Y * speed},
+        rotationSpeed: (-0.02 + rand.Float64()*0.04) * (1 + 0.10*float64(difficulty)),
+        sprite:        sprite,
+        speed:         speed,
+    }
+}
+
+func (m *SeekerMeteor) Update(player *Player) {
+    angle := math.Atan2(player.position.Y-m.position.Y, player.position.X-m.position.X)
+    desired := Vector{X: math.Cos(angle), Y: math.Sin(angle)}
+    old := m.movement.Normalize()
+    heading := Vector{
+        X: old.X*0.9 + desired.X*0.1,
+        Y: old.Y*0.9 + desired.Y*0.1,
+    }.Normalize()
+    m.movement = Vector{X: heading.X * m.speed, Y: heading.Y * m.speed}
+    m.position.X += m.movement.X
+    m.position.Y += m.movement.Y
+    m.rotation += m.rotationSpeed
+}
+
+func (m *SeekerMeteor) Draw(screen *ebiten.Image) {
+    b := m.sprite.Bounds()
+    halfW := float64(b.Dx()) / 2
+    halfH := float64(b.Dy()) / 2
+    op := &ebiten.DrawImageOptions{}
+    op.GeoM.Translate(-halfW, -halfH)
+    op.GeoM.Rotate(m.rotation)
+    op.GeoM.Translate(halfW, halfH)
+    op.GeoM.Translate(m.position.X, m.position.Y)
+    screen.DrawImage(m.sprite, op)
+}
+
+func (m *SeekerMeteor) Collider() Rect {
+    b := m.sprite.Bounds()
+    return NewRect(m.position.X, m.position.Y,
+        float64(b.Dx()), float64(b.Dy()))
+}
+
+// EvasiveBat drifts passively until the player closes within seekDistance,
+// then flees directly away, picking a fresh escape speed between minSpeed
+// and maxSpeed on every tick the player stays within range.
+type EvasiveBat struct {
+    position     Vector
+    movement     Vector
+    rotation     float64
+    sprite       *ebiten.Image
+    seekDistance float64
+    minSpeed     float64
+    maxSpeed     float64
+}
+
+func NewEvasiveBat(difficulty int) *EvasiveBat {
+    sprite := MeteorSprites[rand.Intn(len(MeteorSprites))]
+    pos := spawnOnRing()
+    dir := Vector{X: ScreenWidth/2 - pos.X, Y: ScreenHeight/2 - pos.Y}.Normalize()
+    drift := 0.2 + rand.Float64()*0.2
+    return &EvasiveBat{
+        position:     pos,
+        movement:     Vector{X: dir.X * drift, Y: dir.Y * drift},
+        sprite:       sprite,
+        seekDistance: 150,
+        minSpeed:     1.0 * (1 + 0.10*float64(difficulty)),
+        maxSpeed:     2.2 * (1 + 0.10*float64(difficulty)),
+    }
+}
+
+func (b *EvasiveBat) Update(player *Player) {
+    toPlayer := Vector{X: player.position.X - b.position.X, Y: player.position.Y - b.position.Y}
+    if toPlayer.Len() < b.seekDistance {
+        flee := Vector{X: -toPlayer.X, Y: -toPlayer.Y}.Normalize()
+        speed := b.minSpeed + rand.Float64()*(b.maxSpeed-b.minSpeed)
+        b.movement = Vector{X: flee.X * speed, Y: flee.Y * speed}
+    }
+    b.position.X += b.movement.X
+    b.position.Y += b.movement.Y
+    b.rotation = math.Atan2(b.movement.Y, b.movement.X)
+}
+
+func (b *EvasiveBat) Draw(screen *ebiten.Image) {
+    bounds := b.sprite.Bounds()
+    halfW := float64(bounds.Dx()) / 2
+    halfH := float64(bounds.Dy()) / 2
+    op := &ebiten.DrawImageOptions{}
+    op.GeoM.Translate(-halfW, -halfH)
+    op.GeoM.Rotate(b.rotation)
+    op.GeoM.Translate(halfW, halfH)
+    op.GeoM.Translate(b.position.X, b.position.Y)
+    screen.DrawImage(b.sprite, op)
+}
+
+func (b *EvasiveBat) Collider() Rect {
+    bounds := b.sprite.Bounds()
+    return NewRect(b.position.X, b.position.Y,
+        float64(bounds.Dx()), float64(bounds.Dy()))
+}
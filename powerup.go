@@ -0,0 +1,141 @@
+package main
+
+import (
+    "fmt"
+    "image/color"
+    "math"
+    "math/rand"
+    "time"
+
+    "github.com/hajimehoshi/ebiten/v2"
+    "github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// PowerupKind identifies which pickup variant a Powerup spawned as.
+type PowerupKind int
+
+const (
+    PowerupShield PowerupKind = iota
+    PowerupHolyWater
+    PowerupRapidFire
+)
+
+// EffectKind identifies a timed buff currently active on the Player.
+type EffectKind int
+
+const (
+    EffectShield EffectKind = iota
+    EffectRapidFire
+)
+
+var (
+    PowerupSprites map[PowerupKind]*ebiten.Image
+    effectNames    = map[EffectKind]string{
+        EffectShield:    "Shield",
+        EffectRapidFire: "Rapid Fire",
+    }
+    // effectPowerupKind maps a timed effect back to the Powerup sprite it
+    // was picked up from, so the HUD can draw its icon.
+    effectPowerupKind = map[EffectKind]PowerupKind{
+        EffectShield:    PowerupShield,
+        EffectRapidFire: PowerupRapidFire,
+    }
+    // effectOrder is a fixed draw order for active effects, since ranging
+    // over p.activeEffects directly would jitter the HUD between frames.
+    effectOrder = []EffectKind{EffectShield, EffectRapidFire}
+)
+
+const (
+    shieldDuration    = 5 * time.Second
+    rapidFireDuration = 6 * time.Second
+    rapidFireFactor   = 0.35
+    powerupLifetime   = 8 * time.Second
+)
+
+func loadPowerupSprites() {
+    PowerupSprites = map[PowerupKind]*ebiten.Image{
+        PowerupShield:    mustLoadImage("assets/powerups/shield.png"),
+        PowerupHolyWater: mustLoadImage("assets/powerups/holywater.png"),
+        PowerupRapidFire: mustLoadImage("assets/powerups/rapidfire.png"),
+    }
+}
+
+// Powerup is a pickup that drifts in place until collected or it expires.
+type Powerup struct {
+    position Vector
+    kind     PowerupKind
+    sprite   *ebiten.Image
+    lifetime *Timer
+}
+
+func NewPowerup() *Powerup {
+    kind := PowerupKind(rand.Intn(3))
+    sprite := PowerupSprites[kind]
+    b := sprite.Bounds()
+    pos := Vector{
+        X: rand.Float64() * float64(ScreenWidth-b.Dx()),
+        Y: rand.Float64() * float64(ScreenHeight-b.Dy()),
+    }
+    return &Powerup{
+        position: pos,
+        kind:     kind,
+        sprite:   sprite,
+        lifetime: NewTimer(powerupLifetime),
+    }
+}
+
+func (pu *Powerup) Update() {
+    pu.lifetime.Update()
+}
+
+func (pu *Powerup) Expired() bool {
+    return pu.lifetime.IsReady()
+}
+
+func (pu *Powerup) Draw(screen *ebiten.Image) {
+    op := &ebiten.DrawImageOptions{}
+    op.GeoM.Translate(pu.position.X, pu.position.Y)
+    screen.DrawImage(pu.sprite, op)
+}
+
+func (pu *Powerup) Collider() Rect {
+    b := pu.sprite.Bounds()
+    return NewRect(pu.position.X, pu.position.Y,
+        float64(b.Dx()), float64(b.Dy()))
+}
+
+// applyPowerup resolves the effect of picking up a Powerup of the given kind.
+func (p *Player) applyPowerup(kind PowerupKind) {
+    switch kind {
+    case PowerupShield:
+        p.activeEffects[EffectShield] = NewTimer(shieldDuration)
+    case PowerupRapidFire:
+        p.activeEffects[EffectRapidFire] = NewTimer(rapidFireDuration)
+    case PowerupHolyWater:
+        p.game.score += len(p.game.meteors)
+        p.game.meteors = p.game.meteors[:0]
+    }
+}
+
+// drawActiveEffects renders the player's active timed effects as an icon
+// plus remaining time, under the score. effectOrder keeps draw position
+// stable across frames rather than ranging over the activeEffects map.
+func drawActiveEffects(screen *ebiten.Image, p *Player) {
+    x, y := 10, 80
+    for _, kind := range effectOrder {
+        t, ok := p.activeEffects[kind]
+        if !ok {
+            continue
+        }
+        if icon := PowerupSprites[effectPowerupKind[kind]]; icon != nil {
+            op := &ebiten.DrawImageOptions{}
+            op.GeoM.Scale(0.5, 0.5)
+            op.GeoM.Translate(float64(x), float64(y))
+            screen.DrawImage(icon, op)
+        }
+        remaining := math.Max(0, float64(t.targetTicks-t.currentTicks)/float64(ebiten.TPS()))
+        label := fmt.Sprintf("%s: %.1fs", effectNames[kind], remaining)
+        text.Draw(screen, label, ScoreFont, x+28, y+16, color.White)
+        y += 28
+    }
+}
@@ -0,0 +1,90 @@
+package main
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// rewindHistorySeconds bounds how far back the rewind ability can reach.
+const rewindHistorySeconds = 10
+
+// rewindSpeed is how many snapshots are consumed per real tick the rewind
+// control is held, i.e. roughly how much faster than real time the rewind plays.
+const rewindSpeed = 2
+
+// maxHistoryTicks can't be a const since it depends on ebiten.TPS().
+func maxHistoryTicks() int {
+    return rewindHistorySeconds * ebiten.TPS()
+}
+
+// PlayerSnapshot captures enough Player state at a tick to rewind to it.
+type PlayerSnapshot struct {
+    position Vector
+    rotation float64
+    tick     int
+}
+
+// positionHistory is a fixed-size ring buffer of PlayerSnapshot: push
+// overwrites the oldest entry once full, and popLatest unwinds it in LIFO
+// order, both in O(1) with no allocation.
+type positionHistory struct {
+    buf   []PlayerSnapshot
+    head  int // index the next push writes to
+    count int // number of valid entries currently stored
+}
+
+func newPositionHistory(capacity int) *positionHistory {
+    return &positionHistory{buf: make([]PlayerSnapshot, capacity)}
+}
+
+func (h *positionHistory) push(snap PlayerSnapshot) {
+    h.buf[h.head] = snap
+    h.head = (h.head + 1) % len(h.buf)
+    if h.count < len(h.buf) {
+        h.count++
+    }
+}
+
+// popLatest removes and returns the most recently pushed snapshot.
+func (h *positionHistory) popLatest() (PlayerSnapshot, bool) {
+    if h.count == 0 {
+        return PlayerSnapshot{}, false
+    }
+    h.head = (h.head - 1 + len(h.buf)) % len(h.buf)
+    h.count--
+    return h.buf[h.head], true
+}
+
+func (h *positionHistory) Len() int {
+    return h.count
+}
+
+// recordSnapshot pushes the player's current state onto its rewind ring
+// buffer, overwriting the oldest entry once it's full.
+func (p *Player) recordSnapshot(tick int) {
+    p.positionHistory.push(PlayerSnapshot{
+        position: p.position,
+        rotation: p.rotation,
+        tick:     tick,
+    })
+}
+
+// rewind pops snapshots off the player's history and restores them,
+// consuming rewindSpeed of them per real tick. Meteors and bullets are left
+// untouched while rewinding, so they appear frozen. If the rewind reaches a
+// tick before the one that ended the run, play resumes from there and the
+// provisional high-score entry that death produced is undone, since it was
+// never actually final.
+func (g *Game) rewind() {
+    for i := 0; i < rewindSpeed; i++ {
+        snap, ok := g.player.positionHistory.popLatest()
+        if !ok {
+            return
+        }
+        g.player.position = snap.position
+        g.player.rotation = snap.rotation
+        g.tick = snap.tick
+        if g.isGameOver && snap.tick < g.gameOverTick {
+            g.isGameOver = false
+            g.highScores = g.preDeathHighScores
+            g.lastRank = 0
+        }
+    }
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "image/color"
+    "os"
+    "path/filepath"
+    "sort"
+    "time"
+
+    "github.com/hajimehoshi/ebiten/v2"
+    "github.com/hajimehoshi/ebiten/v2/text"
+    "golang.org/x/text/language"
+    "golang.org/x/text/message"
+)
+
+// numberPrinter gives score readouts digit grouping instead of the old
+// hard-coded %06d padding. It always formats for the English locale.
+var numberPrinter = message.NewPrinter(language.English)
+
+const maxHighScores = 10
+
+// HighScoreEntry is one row of the persisted top-10 table.
+type HighScoreEntry struct {
+    Score int       `json:"score"`
+    Date  time.Time `json:"date"`
+}
+
+func highScoresPath() (string, error) {
+    dir, err := os.UserConfigDir()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(dir, "starship", "scores.json"), nil
+}
+
+// loadHighScores reads the persisted table, returning nil if it doesn't
+// exist yet or can't be read.
+func loadHighScores() []HighScoreEntry {
+    path, err := highScoresPath()
+    if err != nil {
+        return nil
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil
+    }
+    var scores []HighScoreEntry
+    if err := json.Unmarshal(data, &scores); err != nil {
+        return nil
+    }
+    return scores
+}
+
+func saveHighScores(scores []HighScoreEntry) {
+    path, err := highScoresPath()
+    if err != nil {
+        return
+    }
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        return
+    }
+    data, err := json.MarshalIndent(scores, "", "  ")
+    if err != nil {
+        return
+    }
+    _ = os.WriteFile(path, data, 0o644)
+}
+
+// recordHighScore inserts score into the sorted top-10 table, returning the
+// updated table and the 1-based rank score achieved, or 0 if it didn't
+// place. It does not persist anything: a death can still be rewound away,
+// so the caller is responsible for calling saveHighScores once the run is
+// actually confirmed over.
+func recordHighScore(scores []HighScoreEntry, score int, when time.Time) ([]HighScoreEntry, int) {
+    entry := HighScoreEntry{Score: score, Date: when}
+    scores = append(scores, entry)
+    sort.SliceStable(scores, func(i, j int) bool { return scores[i].Score > scores[j].Score })
+    if len(scores) > maxHighScores {
+        scores = scores[:maxHighScores]
+    }
+    rank := 0
+    for i, s := range scores {
+        if s == entry {
+            rank = i + 1
+            break
+        }
+    }
+    return scores, rank
+}
+
+// drawHighScores renders the top-10 table, highlighting the rank the
+// just-finished run achieved, if any. Drawn beneath the rainbow "Try Again"
+// text on the game-over screen.
+func drawHighScores(screen *ebiten.Image, g *Game) {
+    x := ScreenWidth - 220
+    y := ScreenHeight*3/4 + 40
+    text.Draw(screen, "High Scores", ScoreFont, x, y, color.White)
+    y += 30
+    for i, s := range g.highScores {
+        clr := color.Color(color.White)
+        if g.lastRank == i+1 {
+            clr = color.RGBA{R: 255, G: 215, B: 0, A: 255}
+        }
+        line := numberPrinter.Sprintf("%2d. %d", i+1, s.Score)
+        text.Draw(screen, line, ScoreFont, x, y, clr)
+        y += 24
+    }
+}
+
+// drawRunStats renders the stats for the run that just ended, beneath the
+// rainbow "Try Again" text on the game-over screen.
+func drawRunStats(screen *ebiten.Image, g *Game) {
+    accuracy := 0.0
+    if g.shotsFired > 0 {
+        accuracy = float64(g.hits) / float64(g.shotsFired) * 100
+    }
+    survivalSeconds := float64(g.gameOverTick) / float64(ebiten.TPS())
+
+    lines := []string{
+        numberPrinter.Sprintf("Shots fired: %d", g.shotsFired),
+        fmt.Sprintf("Accuracy: %.1f%%", accuracy),
+        fmt.Sprintf("Survival: %.1fs", survivalSeconds),
+        numberPrinter.Sprintf("Peak difficulty: %d", g.peakDifficulty),
+    }
+    x, y := 40, ScreenHeight*3/4+40
+    for _, line := range lines {
+        text.Draw(screen, line, ScoreFont, x, y, color.White)
+        y += 24
+    }
+}